@@ -3,9 +3,13 @@ package main
 import (
 	"fmt"
 	"os"
+	"regexp"
 
+	"github.com/charmbracelet/huh"
+	"github.com/daedalus/scaffold/internal/components"
 	"github.com/daedalus/scaffold/internal/config"
 	"github.com/daedalus/scaffold/internal/generator"
+	"github.com/daedalus/scaffold/internal/project"
 	"github.com/daedalus/scaffold/internal/templates"
 	"github.com/spf13/cobra"
 )
@@ -15,10 +19,28 @@ var (
 	author      string
 	license     string
 	projectType string
+	templateRef string
+	framework   templates.Framework
 	dryRun      bool
 	interactive bool
+
+	gitInit              bool
+	noGit                bool
+	gitRemote            string
+	initialCommitMessage string
+	gpgSign              bool
+	tidy                 bool
+
+	addPackage string
+	addForce   bool
+
+	templatesDir string
 )
 
+// githubModulePattern matches a bare "github.com/<user>/<repo>" module
+// path, used to offer an auto-derived git remote.
+var githubModulePattern = regexp.MustCompile(`^github\.com/[^/]+/[^/]+$`)
+
 func main() {
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
@@ -35,6 +57,9 @@ golang-standards/project-layout structure.
 It helps you quickly set up a well-structured Go project with all the
 standard directories and files needed to get started.`,
 	Version: "1.0.0",
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		templates.UseTemplatesDir(templatesDir)
+	},
 }
 
 var newCmd = &cobra.Command{
@@ -59,15 +84,85 @@ Examples:
 	RunE: runNew,
 }
 
+var addCmd = &cobra.Command{
+	Use:   "add <component-type> <name>",
+	Short: "Add a component to an existing scaffold project",
+	Long: `Add scaffolds an additional component into a project that was
+previously created with "scaffold new", using that project's recorded
+template and configuration.
+
+Supported component types:
+  handler, middleware   (service projects)
+  command                (cli projects)
+  example                (library projects)
+
+Examples:
+  scaffold add handler users
+  scaffold add command serve
+  scaffold add example basic-usage --force`,
+	Args: cobra.ExactArgs(2),
+	RunE: runAdd,
+}
+
 func init() {
+	rootCmd.PersistentFlags().StringVar(&templatesDir, "templates-dir", os.Getenv("SCAFFOLD_TEMPLATES_DIR"), "Read the default template live from this directory instead of the embedded one (or set SCAFFOLD_TEMPLATES_DIR)")
+
 	newCmd.Flags().StringVarP(&modulePath, "module-path", "m", "", "Go module path (e.g., github.com/user/project)")
 	newCmd.Flags().StringVarP(&author, "author", "a", "", "Author name")
 	newCmd.Flags().StringVarP(&license, "license", "l", "MIT", "License type")
 	newCmd.Flags().StringVarP(&projectType, "type", "t", "cli", "Project type (cli, library, service)")
+	newCmd.Flags().StringVar(&templateRef, "template", "", "Template to use: a built-in name, a local directory, or a git URL (e.g. github.com/user/repo@v1)")
+	newCmd.Flags().VarP(&framework, "framework", "f", "Framework to build on (allowed values depend on --type)")
 	newCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Preview what would be created without creating anything")
 	newCmd.Flags().BoolVarP(&interactive, "interactive", "i", false, "Use interactive mode to configure the project")
+	newCmd.Flags().BoolVar(&gitInit, "git", true, "Initialise a git repository and create an initial commit")
+	newCmd.Flags().BoolVar(&noGit, "no-git", false, "Disable git initialisation (shorthand for --git=false)")
+	newCmd.Flags().StringVar(&gitRemote, "git-remote", "", "Git remote URL to register as \"origin\"")
+	newCmd.Flags().StringVar(&initialCommitMessage, "initial-commit-message", "", "Message for the initial commit (default: \"Initial commit from scaffold\")")
+	newCmd.Flags().BoolVar(&gpgSign, "gpg-sign", false, "GPG-sign the initial commit")
+	newCmd.Flags().BoolVar(&tidy, "tidy", false, "Run `go mod tidy` after generating go.mod")
+
+	newCmd.RegisterFlagCompletionFunc("type", completeProjectType)
+	newCmd.RegisterFlagCompletionFunc("license", completeLicense)
+	newCmd.RegisterFlagCompletionFunc("framework", completeFramework)
 
 	rootCmd.AddCommand(newCmd)
+
+	addCmd.Flags().StringVar(&addPackage, "package", "", "Go package name for the generated component (defaults to the component type)")
+	addCmd.Flags().BoolVar(&addForce, "force", false, "Overwrite existing files")
+
+	rootCmd.AddCommand(addCmd)
+}
+
+// availableLicenses are the choices offered for --license; unlike
+// --framework these don't depend on --type.
+var availableLicenses = []string{"MIT", "Apache-2.0", "GPL-3.0", "BSD-3-Clause", "None"}
+
+// completeProjectType completes --type with the three supported project
+// types.
+func completeProjectType(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return []string{string(templates.TypeCLI), string(templates.TypeLibrary), string(templates.TypeService)}, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeLicense completes --license with the supported license choices.
+func completeLicense(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return availableLicenses, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeFramework completes --framework with only the frameworks valid
+// for the currently-selected --type.
+func completeFramework(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	pType, err := cmd.Flags().GetString("type")
+	if err != nil {
+		pType = string(templates.TypeCLI)
+	}
+
+	frameworks := templates.FrameworksFor(templates.ProjectType(pType))
+	completions := make([]string, len(frameworks))
+	for i, fw := range frameworks {
+		completions[i] = string(fw)
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
 }
 
 func runNew(cmd *cobra.Command, args []string) error {
@@ -94,6 +189,8 @@ func runNew(cmd *cobra.Command, args []string) error {
 		Author:      author,
 		License:     license,
 		Type:        pType,
+		Framework:   framework,
+		Template:    templateRef,
 	}
 
 	// Use interactive mode if explicitly requested or if no project name was provided
@@ -121,14 +218,60 @@ func runNew(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// --framework is only checked against the full set of known
+	// frameworks at flag-parse time; validate it against the selected
+	// --type now that both are final.
+	if cfg.Framework != templates.FrameworkNone {
+		valid := false
+		for _, fw := range templates.FrameworksFor(cfg.Type) {
+			if fw == cfg.Framework {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return fmt.Errorf("framework %q is not valid for %s projects", cfg.Framework, cfg.Type)
+		}
+	}
+
 	// Get current working directory
 	cwd, err := os.Getwd()
 	if err != nil {
 		return fmt.Errorf("failed to get current directory: %w", err)
 	}
 
+	gitEnabled := gitInit && !noGit
+
+	// If the module path looks like a GitHub repo and no remote was given
+	// explicitly, offer to derive one automatically.
+	remote := gitRemote
+	if gitEnabled && remote == "" && githubModulePattern.MatchString(cfg.ModulePath) {
+		derived := "https://" + cfg.ModulePath + ".git"
+		if interactive {
+			var useRemote bool
+			confirm := huh.NewConfirm().
+				Title("Set git remote?").
+				Description(fmt.Sprintf("Use %s as the \"origin\" remote?", derived)).
+				Value(&useRemote)
+			if err := confirm.Run(); err == nil && useRemote {
+				remote = derived
+			}
+		} else {
+			remote = derived
+		}
+	}
+
 	// Create generator and generate project
-	gen := generator.New(cfg, cwd, dryRun)
+	gen := generator.New(cfg, cwd, generator.Options{
+		DryRun: dryRun,
+		Git: generator.GitOptions{
+			Enabled: gitEnabled,
+			Remote:  remote,
+			Message: initialCommitMessage,
+			GPGSign: gpgSign,
+		},
+		Tidy: tidy,
+	})
 	if err := gen.Generate(); err != nil {
 		return err
 	}
@@ -143,3 +286,41 @@ func runNew(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+func runAdd(cmd *cobra.Command, args []string) error {
+	kind := components.Kind(args[0])
+	name := args[1]
+
+	if err := generator.ValidateProjectName(name); err != nil {
+		return fmt.Errorf("invalid component name: %w", err)
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	marker, projectRoot, err := project.Find(cwd)
+	if err != nil {
+		return err
+	}
+
+	pkg := addPackage
+	if pkg == "" {
+		pkg = string(kind)
+	}
+
+	opts := components.Options{
+		Kind:    kind,
+		Name:    name,
+		Package: pkg,
+		Force:   addForce,
+	}
+
+	if err := components.Add(projectRoot, marker, opts); err != nil {
+		return err
+	}
+
+	fmt.Printf("✓ Added %s %q to %s\n", kind, name, projectRoot)
+	return nil
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"reflect"
+	"strings"
+	"text/template"
+	"text/template/parse"
+
+	"github.com/daedalus/scaffold/internal/templates"
+	"github.com/spf13/cobra"
+)
+
+var templatesCmd = &cobra.Command{
+	Use:   "templates",
+	Short: "Inspect template trees",
+}
+
+var templatesValidateCmd = &cobra.Command{
+	Use:   "validate <dir>",
+	Short: "Parse every template in dir and report errors",
+	Long: `Validate walks dir, parses every .tmpl file, and reports parse
+errors with file and line information. It also flags any field
+referenced on the template's top-level value (e.g. {{ .Foo }}) that
+isn't a field on templates.ProjectConfig, so a stray {{ .Typo }} is
+caught before it reaches a generated project.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTemplatesValidate,
+}
+
+func init() {
+	templatesCmd.AddCommand(templatesValidateCmd)
+	rootCmd.AddCommand(templatesCmd)
+}
+
+func runTemplatesValidate(cmd *cobra.Command, args []string) error {
+	dir := args[0]
+	fsys := os.DirFS(dir)
+	known := projectConfigFields()
+
+	var (
+		checked     int
+		parseErrors []string
+		badVars     []string
+	)
+
+	err := fs.WalkDir(fsys, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(path, ".tmpl") {
+			return nil
+		}
+		checked++
+
+		content, err := fs.ReadFile(fsys, path)
+		if err != nil {
+			parseErrors = append(parseErrors, fmt.Sprintf("%s: %v", path, err))
+			return nil
+		}
+
+		tmpl, err := template.New(path).Parse(string(content))
+		if err != nil {
+			parseErrors = append(parseErrors, fmt.Sprintf("%s: %v", path, err))
+			return nil
+		}
+
+		for _, name := range undeclaredFields(tmpl, known) {
+			badVars = append(badVars, fmt.Sprintf("%s: references unknown variable %q", path, name))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to walk %s: %w", dir, err)
+	}
+
+	for _, e := range parseErrors {
+		fmt.Println("✗", e)
+	}
+	for _, w := range badVars {
+		fmt.Println("⚠", w)
+	}
+
+	fmt.Printf("\nChecked %d template(s): %d parse error(s), %d unknown variable(s)\n", checked, len(parseErrors), len(badVars))
+
+	if len(parseErrors) > 0 {
+		return fmt.Errorf("template validation failed")
+	}
+
+	return nil
+}
+
+// projectConfigFields returns the set of exported field names on
+// templates.ProjectConfig, the data project templates are executed
+// against. Component templates run against other data and are checked
+// against these fields too.
+func projectConfigFields() map[string]bool {
+	t := reflect.TypeOf(templates.ProjectConfig{})
+	fields := make(map[string]bool, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		fields[t.Field(i).Name] = true
+	}
+	return fields
+}
+
+// undeclaredFields walks tmpl's parse tree and returns the names of any
+// top-level field references (".Foo") that aren't in known.
+func undeclaredFields(tmpl *template.Template, known map[string]bool) []string {
+	if tmpl.Tree == nil {
+		return nil
+	}
+
+	var bad []string
+	seen := map[string]bool{}
+
+	var walkNodes func(nodes []parse.Node)
+	walkPipe := func(p *parse.PipeNode) {
+		if p == nil {
+			return
+		}
+		for _, cmd := range p.Cmds {
+			walkNodes(cmd.Args)
+		}
+	}
+	walkNodes = func(nodes []parse.Node) {
+		for _, n := range nodes {
+			switch v := n.(type) {
+			case *parse.ActionNode:
+				walkPipe(v.Pipe)
+			case *parse.FieldNode:
+				if len(v.Ident) > 0 {
+					name := v.Ident[0]
+					if !known[name] && !seen[name] {
+						seen[name] = true
+						bad = append(bad, name)
+					}
+				}
+			case *parse.IfNode:
+				walkPipe(v.Pipe)
+				walkNodes(v.List.Nodes)
+				if v.ElseList != nil {
+					walkNodes(v.ElseList.Nodes)
+				}
+			case *parse.RangeNode:
+				// Dot is rebound inside the body, so only the pipeline
+				// and else branch refer to the top-level value.
+				walkPipe(v.Pipe)
+				if v.ElseList != nil {
+					walkNodes(v.ElseList.Nodes)
+				}
+			case *parse.WithNode:
+				walkPipe(v.Pipe)
+				if v.ElseList != nil {
+					walkNodes(v.ElseList.Nodes)
+				}
+			}
+		}
+	}
+
+	walkNodes(tmpl.Tree.Root.Nodes)
+	return bad
+}
@@ -0,0 +1,154 @@
+// Package components renders additional pieces into a project that was
+// already generated by scaffold, via `scaffold add`.
+package components
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/daedalus/scaffold/internal/project"
+	"github.com/daedalus/scaffold/internal/templates"
+)
+
+// Kind identifies the category of component being added.
+type Kind string
+
+const (
+	KindHandler    Kind = "handler"
+	KindMiddleware Kind = "middleware"
+	KindCommand    Kind = "command"
+	KindExample    Kind = "example"
+)
+
+// allowedKinds maps each project type to the component kinds it supports.
+var allowedKinds = map[templates.ProjectType][]Kind{
+	templates.TypeService: {KindHandler, KindMiddleware},
+	templates.TypeCLI:     {KindCommand},
+	templates.TypeLibrary: {KindExample},
+}
+
+// Options configures Add.
+type Options struct {
+	Kind    Kind
+	Name    string
+	Package string
+	Force   bool
+}
+
+// templateData is what component templates are executed against.
+type templateData struct {
+	*project.Marker
+	Name    string
+	Package string
+}
+
+// Add renders the kind's templates into an existing project rooted at
+// projectRoot, using marker for project-wide variables. It refuses to
+// overwrite existing files unless opts.Force is set.
+func Add(projectRoot string, marker *project.Marker, opts Options) error {
+	if err := validateKind(marker.Type, opts.Kind); err != nil {
+		return err
+	}
+
+	src, err := templates.ResolveTemplate(marker.Template)
+	if err != nil {
+		return fmt.Errorf("failed to resolve template %s: %w", marker.Template, err)
+	}
+	fsys, err := src.FS()
+	if err != nil {
+		return fmt.Errorf("failed to load template %s: %w", marker.Template, err)
+	}
+
+	componentDir := filepath.Join("components", string(marker.Type), string(opts.Kind))
+	entries, err := fs.ReadDir(fsys, componentDir)
+	if err != nil {
+		return fmt.Errorf("no %s component templates found for %s projects: %w", opts.Kind, marker.Type, err)
+	}
+
+	destDir := filepath.Join(projectRoot, componentDestDir(opts.Kind, marker, opts.Name))
+	data := templateData{Marker: marker, Name: opts.Name, Package: opts.Package}
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		tmplPath := filepath.Join(componentDir, entry.Name())
+		tmpl, err := templates.GetTemplate(fsys, tmplPath)
+		if err != nil {
+			return fmt.Errorf("failed to load template %s: %w", tmplPath, err)
+		}
+
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, data); err != nil {
+			return fmt.Errorf("failed to execute template %s: %w", tmplPath, err)
+		}
+
+		destPath := filepath.Join(destDir, componentFileName(entry.Name(), opts.Name))
+		if err := writeComponentFile(destPath, buf.Bytes(), opts.Force); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateKind reports whether kind is supported for project type pType.
+func validateKind(pType templates.ProjectType, kind Kind) error {
+	for _, k := range allowedKinds[pType] {
+		if k == kind {
+			return nil
+		}
+	}
+	return fmt.Errorf("component type %q is not valid for %s projects", kind, pType)
+}
+
+// componentDestDir returns the directory a component's rendered files are
+// written into, relative to the project root.
+func componentDestDir(kind Kind, marker *project.Marker, name string) string {
+	switch kind {
+	case KindHandler:
+		return filepath.Join("internal", "handlers")
+	case KindMiddleware:
+		return filepath.Join("internal", "middleware")
+	case KindCommand:
+		return filepath.Join("cmd", marker.ProjectName, "cmd")
+	case KindExample:
+		return filepath.Join("examples", name)
+	default:
+		return "."
+	}
+}
+
+// componentFileName derives a destination file name from a template file
+// name by stripping the .tmpl suffix and substituting the component's
+// name for the literal "component" placeholder, e.g.
+// "component.go.tmpl" -> "users.go" for a component named "users".
+func componentFileName(templateFileName, name string) string {
+	base := strings.TrimSuffix(templateFileName, ".tmpl")
+	return strings.ReplaceAll(base, "component", name)
+}
+
+// writeComponentFile writes content to path, refusing to overwrite an
+// existing file unless force is set.
+func writeComponentFile(path string, content []byte, force bool) error {
+	if !force {
+		if _, err := os.Stat(path); err == nil {
+			return fmt.Errorf("%s already exists (use --force to overwrite)", path)
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", path, err)
+	}
+
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	return nil
+}
@@ -10,6 +10,7 @@ import (
 // PromptForMissingConfig prompts the user for any missing configuration
 func PromptForMissingConfig(config *templates.ProjectConfig) error {
 	var projectType string
+	frameworkStr := string(config.Framework)
 
 	// Convert ProjectType to string for the form
 	switch config.Type {
@@ -80,8 +81,39 @@ func PromptForMissingConfig(config *templates.ProjectConfig) error {
 				huh.NewOption("Service/API", "service"),
 			).
 			Value(&projectType),
+
+		huh.NewSelect[string]().
+			Title("Framework").
+			Description("Pick a framework to build on (options depend on project type)").
+			OptionsFunc(func() []huh.Option[string] {
+				options := []huh.Option[string]{huh.NewOption("None", "")}
+				for _, fw := range templates.FrameworksFor(templates.ProjectType(projectType)) {
+					options = append(options, huh.NewOption(string(fw), string(fw)))
+				}
+				return options
+			}, &projectType).
+			Value(&frameworkStr),
 	)
 
+	// Offer a template picker if any named built-in templates are
+	// available in addition to the default one.
+	if config.Template == "" {
+		if names, err := templates.ListBuiltinTemplates(); err == nil && len(names) > 0 {
+			options := []huh.Option[string]{huh.NewOption("default", "default")}
+			for _, name := range names {
+				options = append(options, huh.NewOption(name, name))
+			}
+
+			fields = append(fields,
+				huh.NewSelect[string]().
+					Title("Template").
+					Description("Which template should generate this project?").
+					Options(options...).
+					Value(&config.Template),
+			)
+		}
+	}
+
 	form := huh.NewForm(
 		huh.NewGroup(fields...),
 	)
@@ -92,6 +124,7 @@ func PromptForMissingConfig(config *templates.ProjectConfig) error {
 
 	// Update config with form values
 	config.Type = templates.ProjectType(projectType)
+	config.Framework = templates.Framework(frameworkStr)
 
 	// Set default module path if still empty
 	if config.ModulePath == "" {
@@ -103,5 +136,10 @@ func PromptForMissingConfig(config *templates.ProjectConfig) error {
 		config.Author = "Your Name"
 	}
 
+	// Set default template if still empty
+	if config.Template == "" {
+		config.Template = "default"
+	}
+
 	return nil
 }
@@ -3,38 +3,71 @@ package generator
 import (
 	"bytes"
 	"fmt"
+	"io/fs"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strings"
 
+	"github.com/daedalus/scaffold/internal/project"
 	"github.com/daedalus/scaffold/internal/templates"
+	"github.com/daedalus/scaffold/internal/vcs"
 )
 
+// GitOptions configures the optional post-generation git init step.
+type GitOptions struct {
+	Enabled bool
+	Remote  string
+	Message string
+	GPGSign bool
+}
+
+// Options configures optional post-generation steps.
+type Options struct {
+	DryRun bool
+	Git    GitOptions
+	// Tidy runs `go mod tidy` in the generated project after go.mod is
+	// written.
+	Tidy bool
+}
+
 // Generator handles project generation
 type Generator struct {
 	config  *templates.ProjectConfig
 	baseDir string
-	dryRun  bool
+	opts    Options
+
+	// source and manifest are populated by resolveSource. manifest is nil
+	// when generating from the built-in default template, in which case
+	// the hard-coded directory structure and file map below are used
+	// instead.
+	source   templates.Source
+	fsys     fs.FS
+	manifest *templates.Manifest
 }
 
 // New creates a new Generator instance
-func New(config *templates.ProjectConfig, baseDir string, dryRun bool) *Generator {
+func New(config *templates.ProjectConfig, baseDir string, opts Options) *Generator {
 	return &Generator{
 		config:  config,
 		baseDir: baseDir,
-		dryRun:  dryRun,
+		opts:    opts,
 	}
 }
 
 // Generate creates the project structure
 func (g *Generator) Generate() error {
+	if err := g.resolveSource(); err != nil {
+		return err
+	}
+
 	// Check if directory already exists
 	projectPath := filepath.Join(g.baseDir, g.config.ProjectName)
 	if _, err := os.Stat(projectPath); err == nil {
 		return fmt.Errorf("directory %s already exists", projectPath)
 	}
 
-	if g.dryRun {
+	if g.opts.DryRun {
 		fmt.Println("Dry run mode - no files will be created")
 		return g.preview()
 	}
@@ -54,13 +87,111 @@ func (g *Generator) Generate() error {
 		return err
 	}
 
+	// Record how the project was generated so `scaffold add` can find and
+	// extend it later. A failure here shouldn't discard the generated
+	// tree, so it's reported but not fatal.
+	if err := g.writeMarker(projectPath); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+
+	// Post-processing steps below are best-effort: a failure is reported
+	// but must not discard the generated tree.
+	if g.opts.Tidy {
+		if err := g.runGoModTidy(projectPath); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+	}
+
+	if g.opts.Git.Enabled {
+		if err := g.initGit(projectPath); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+	}
+
 	fmt.Printf("✓ Project %s created successfully at %s\n", g.config.ProjectName, projectPath)
 	return nil
 }
 
+// runGoModTidy runs `go mod tidy` in the generated project.
+func (g *Generator) runGoModTidy(projectPath string) error {
+	cmd := exec.Command("go", "mod", "tidy")
+	cmd.Dir = projectPath
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("go mod tidy failed: %w\n%s", err, out)
+	}
+	return nil
+}
+
+// initGit initialises the generated project as a git repository with an
+// initial commit.
+func (g *Generator) initGit(projectPath string) error {
+	if err := vcs.Init(projectPath, vcs.InitOptions{
+		Author:  g.config.Author,
+		Remote:  g.opts.Git.Remote,
+		Message: g.opts.Git.Message,
+		GPGSign: g.opts.Git.GPGSign,
+	}); err != nil {
+		return fmt.Errorf("failed to initialise git repository: %w", err)
+	}
+	return nil
+}
+
+// writeMarker persists a project.Marker recording this generation.
+func (g *Generator) writeMarker(projectPath string) error {
+	templateRef := g.config.Template
+	if templateRef == "" {
+		templateRef = "default"
+	}
+
+	marker := &project.Marker{
+		ProjectName: g.config.ProjectName,
+		ModulePath:  g.config.ModulePath,
+		Type:        g.config.Type,
+		Template:    templateRef,
+	}
+	if g.manifest != nil {
+		marker.TemplateVersion = g.manifest.Version
+	}
+
+	if err := marker.Write(projectPath); err != nil {
+		return fmt.Errorf("failed to write %s: %w", project.MarkerFile, err)
+	}
+
+	return nil
+}
+
+// resolveSource resolves g.config.Template into a Source and, for
+// anything other than the built-in default template, loads its manifest.
+func (g *Generator) resolveSource() error {
+	src, err := templates.ResolveTemplate(g.config.Template)
+	if err != nil {
+		return fmt.Errorf("failed to resolve template: %w", err)
+	}
+
+	fsys, err := src.FS()
+	if err != nil {
+		return fmt.Errorf("failed to load template %s: %w", src.Describe(), err)
+	}
+
+	g.source = src
+	g.fsys = fsys
+
+	if g.config.Template == "" || g.config.Template == "default" {
+		return nil
+	}
+
+	manifest, err := templates.LoadManifest(fsys)
+	if err != nil {
+		return fmt.Errorf("failed to load template %s: %w", src.Describe(), err)
+	}
+	g.manifest = manifest
+
+	return nil
+}
+
 // createDirectories creates the project directory structure
 func (g *Generator) createDirectories(projectPath string) error {
-	dirs := templates.DirectoryStructure(g.config.Type)
+	dirs := g.directories()
 
 	for _, dir := range dirs {
 		dirPath := filepath.Join(projectPath, dir)
@@ -69,6 +200,13 @@ func (g *Generator) createDirectories(projectPath string) error {
 		}
 	}
 
+	// Manifest-driven templates declare cmd/<project> and workflow
+	// directories themselves via Directories/Files; only the built-in
+	// default template needs them created implicitly.
+	if g.manifest != nil {
+		return nil
+	}
+
 	// Create cmd subdirectory for the main application
 	cmdPath := filepath.Join(projectPath, "cmd", g.config.ProjectName)
 	if err := os.MkdirAll(cmdPath, 0755); err != nil {
@@ -84,8 +222,21 @@ func (g *Generator) createDirectories(projectPath string) error {
 	return nil
 }
 
+// directories returns the directories to create: the manifest's, for a
+// resolved external template, or the hard-coded layout for the default.
+func (g *Generator) directories() []string {
+	if g.manifest != nil {
+		return g.manifest.Directories
+	}
+	return templates.DirectoryStructure(g.config.Type)
+}
+
 // generateFiles creates files from templates
 func (g *Generator) generateFiles(projectPath string) error {
+	if g.manifest != nil {
+		return g.generateManifestFiles(projectPath)
+	}
+
 	// Select Makefile template based on project type
 	makefileTemplate := g.getMakefileTemplate()
 
@@ -126,6 +277,18 @@ func (g *Generator) generateFiles(projectPath string) error {
 	return nil
 }
 
+// generateManifestFiles renders every file declared by a resolved
+// external template's manifest.
+func (g *Generator) generateManifestFiles(projectPath string) error {
+	for dest, src := range g.manifest.Files {
+		if err := g.generateFile(projectPath, dest, src); err != nil {
+			return err
+		}
+	}
+
+	return g.createGitkeepFiles(projectPath)
+}
+
 // getMakefileTemplate returns the appropriate Makefile template based on project type
 func (g *Generator) getMakefileTemplate() string {
 	switch g.config.Type {
@@ -172,8 +335,8 @@ func (g *Generator) generateWorkflowFiles(projectPath string) error {
 
 // copyWorkflowFile copies a workflow file without templating (for static files)
 func (g *Generator) copyWorkflowFile(projectPath, destPath, srcPath string) error {
-	// Read the workflow file from embedded templates
-	content, err := templates.GetWorkflowFile(srcPath)
+	// Read the workflow file from the resolved template
+	content, err := templates.GetWorkflowFile(g.fsys, srcPath)
 	if err != nil {
 		return fmt.Errorf("failed to read workflow file %s: %w", srcPath, err)
 	}
@@ -189,7 +352,7 @@ func (g *Generator) copyWorkflowFile(projectPath, destPath, srcPath string) erro
 
 // generateFile generates a single file from a template
 func (g *Generator) generateFile(projectPath, fileName, templateName string) error {
-	tmpl, err := templates.GetTemplate(templateName)
+	tmpl, err := templates.GetTemplate(g.fsys, templateName)
 	if err != nil {
 		return fmt.Errorf("failed to load template %s: %w", templateName, err)
 	}
@@ -209,8 +372,7 @@ func (g *Generator) generateFile(projectPath, fileName, templateName string) err
 
 // createGitkeepFiles creates .gitkeep files in empty directories
 func (g *Generator) createGitkeepFiles(projectPath string) error {
-	// Get the directory structure for this project type
-	dirs := templates.DirectoryStructure(g.config.Type)
+	dirs := g.directories()
 
 	// Directories that will have generated content (skip .gitkeep for these)
 	skipDirs := map[string]bool{
@@ -239,31 +401,52 @@ func (g *Generator) preview() error {
 	fmt.Printf("\nProject: %s\n", g.config.ProjectName)
 	fmt.Printf("Location: %s\n", projectPath)
 	fmt.Printf("Module Path: %s\n", g.config.ModulePath)
-	fmt.Printf("Type: %s\n\n", g.config.Type)
+	fmt.Printf("Type: %s\n", g.config.Type)
+	if g.source != nil {
+		fmt.Printf("Template: %s\n", g.source.Describe())
+	}
+	fmt.Println()
 
 	fmt.Println("Directories to be created:")
-	dirs := templates.DirectoryStructure(g.config.Type)
+	dirs := g.directories()
 	for _, dir := range dirs {
 		fmt.Printf("  %s/\n", dir)
 	}
-	fmt.Printf("  cmd/%s/\n", g.config.ProjectName)
 
 	fmt.Println("\nFiles to be created:")
-	files := []string{
-		"README.md",
-		"Makefile",
-		".gitignore",
-		"go.mod",
-		fmt.Sprintf("cmd/%s/main.go", g.config.ProjectName),
+	if g.manifest != nil {
+		for dest := range g.manifest.Files {
+			fmt.Printf("  %s\n", dest)
+		}
+	} else {
+		fmt.Printf("  cmd/%s/\n", g.config.ProjectName)
+		files := []string{
+			"README.md",
+			"Makefile",
+			".gitignore",
+			"go.mod",
+			fmt.Sprintf("cmd/%s/main.go", g.config.ProjectName),
+		}
+
+		// Add Dockerfile for service projects
+		if g.config.Type == templates.TypeService {
+			files = append(files, "Dockerfile")
+		}
+
+		for _, file := range files {
+			fmt.Printf("  %s\n", file)
+		}
 	}
 
-	// Add Dockerfile for service projects
-	if g.config.Type == templates.TypeService {
-		files = append(files, "Dockerfile")
+	if g.opts.Tidy {
+		fmt.Println("\nWould run: go mod tidy")
 	}
 
-	for _, file := range files {
-		fmt.Printf("  %s\n", file)
+	if g.opts.Git.Enabled {
+		fmt.Println("\nWould initialise a git repository and create an initial commit")
+		if g.opts.Git.Remote != "" {
+			fmt.Printf("Would add remote \"origin\" -> %s\n", g.opts.Git.Remote)
+		}
 	}
 
 	return nil
@@ -0,0 +1,65 @@
+// Package project persists and locates the marker file scaffold writes at
+// the root of every generated project, so later invocations (such as
+// `scaffold add`) can find the project root and recover how it was
+// generated.
+package project
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/daedalus/scaffold/internal/templates"
+	"gopkg.in/yaml.v3"
+)
+
+// MarkerFile is the name of the file Generator.Generate writes at the
+// root of every generated project.
+const MarkerFile = ".scaffold.yaml"
+
+// Marker is the persisted record of how a project was generated.
+type Marker struct {
+	ProjectName     string                `yaml:"project_name"`
+	ModulePath      string                `yaml:"module_path"`
+	Type            templates.ProjectType `yaml:"type"`
+	Template        string                `yaml:"template"`
+	TemplateVersion string                `yaml:"template_version,omitempty"`
+}
+
+// Write persists m as projectPath/.scaffold.yaml.
+func (m *Marker) Write(projectPath string) error {
+	content, err := yaml.Marshal(m)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", MarkerFile, err)
+	}
+
+	if err := os.WriteFile(filepath.Join(projectPath, MarkerFile), content, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", MarkerFile, err)
+	}
+
+	return nil
+}
+
+// Find walks upward from dir looking for MarkerFile, analogous to
+// controller-tools' DieIfNoProject, and returns the parsed Marker along
+// with the directory it was found in. It errors if no marker is found
+// before reaching the filesystem root, so callers refuse to run outside
+// a scaffold-generated project.
+func Find(dir string) (*Marker, string, error) {
+	for {
+		markerPath := filepath.Join(dir, MarkerFile)
+		if content, err := os.ReadFile(markerPath); err == nil {
+			var m Marker
+			if err := yaml.Unmarshal(content, &m); err != nil {
+				return nil, "", fmt.Errorf("failed to parse %s: %w", markerPath, err)
+			}
+			return &m, dir, nil
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, "", fmt.Errorf("not a scaffold project (no %s found in %s or any parent directory)", MarkerFile, dir)
+		}
+		dir = parent
+	}
+}
@@ -0,0 +1,83 @@
+package templates
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Framework is the web or CLI framework a generated project should build
+// on. It implements pflag.Value so `--framework` rejects unknown values
+// at flag-parse time instead of generation time.
+type Framework string
+
+const (
+	FrameworkNone       Framework = ""
+	FrameworkChi        Framework = "chi"
+	FrameworkGin        Framework = "gin"
+	FrameworkFiber      Framework = "fiber"
+	FrameworkEcho       Framework = "echo"
+	FrameworkGorillaMux Framework = "gorilla-mux"
+	FrameworkHTTPRouter Framework = "httprouter"
+	FrameworkNetHTTP    Framework = "net-http"
+	FrameworkCobra      Framework = "cobra"
+	FrameworkURFAVECLI  Framework = "urfave-cli"
+	FrameworkStdlibFlag Framework = "stdlib-flag"
+)
+
+// FrameworksFor returns the frameworks valid for a project type. Library
+// projects have none: they don't own a main entry point to wire a
+// framework into.
+func FrameworksFor(projectType ProjectType) []Framework {
+	switch projectType {
+	case TypeService:
+		return []Framework{FrameworkChi, FrameworkGin, FrameworkFiber, FrameworkEcho, FrameworkGorillaMux, FrameworkHTTPRouter, FrameworkNetHTTP}
+	case TypeCLI:
+		return []Framework{FrameworkCobra, FrameworkURFAVECLI, FrameworkStdlibFlag}
+	default:
+		return nil
+	}
+}
+
+// String implements pflag.Value.
+func (f *Framework) String() string {
+	return string(*f)
+}
+
+// Set implements pflag.Value. It only checks that value is a known
+// framework for *some* project type, since --framework and --type are
+// parsed independently; newCmd re-validates the pair once both flags are
+// available.
+func (f *Framework) Set(value string) error {
+	if value == "" {
+		*f = FrameworkNone
+		return nil
+	}
+
+	for _, pType := range []ProjectType{TypeService, TypeCLI} {
+		for _, candidate := range FrameworksFor(pType) {
+			if string(candidate) == value {
+				*f = Framework(value)
+				return nil
+			}
+		}
+	}
+
+	return fmt.Errorf("invalid framework %q (allowed: %s)", value, strings.Join(allFrameworkNames(), ", "))
+}
+
+// Type implements pflag.Value.
+func (f *Framework) Type() string {
+	return "framework"
+}
+
+// allFrameworkNames returns every known framework name, across all
+// project types, for use in error messages.
+func allFrameworkNames() []string {
+	var names []string
+	for _, pType := range []ProjectType{TypeService, TypeCLI} {
+		for _, fw := range FrameworksFor(pType) {
+			names = append(names, string(fw))
+		}
+	}
+	return names
+}
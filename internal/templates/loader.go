@@ -0,0 +1,46 @@
+package templates
+
+import (
+	"io/fs"
+	"os"
+)
+
+// Loader resolves the filesystem the built-in "default" template is read
+// from. It exists so template authors can point scaffold at a directory
+// on disk and iterate on .tmpl files without rebuilding the binary.
+type Loader interface {
+	FS() (fs.FS, error)
+}
+
+// embeddedLoader serves the default template baked into the binary.
+type embeddedLoader struct{}
+
+func (embeddedLoader) FS() (fs.FS, error) {
+	return fs.Sub(templateFS, "files")
+}
+
+// liveLoader serves the default template from a directory on disk. It
+// does no caching of its own: every GetTemplate/GetWorkflowFile call
+// reads straight from disk, so edits to .tmpl files are picked up
+// immediately.
+type liveLoader struct {
+	dir string
+}
+
+func (l liveLoader) FS() (fs.FS, error) {
+	return os.DirFS(l.dir), nil
+}
+
+// defaultLoader resolves the built-in "default" template; UseTemplatesDir
+// switches it to a live directory for development.
+var defaultLoader Loader = embeddedLoader{}
+
+// UseTemplatesDir switches the default template to be read live from dir.
+// An empty dir is a no-op, so callers can pass a possibly-unset
+// --templates-dir/SCAFFOLD_TEMPLATES_DIR value directly.
+func UseTemplatesDir(dir string) {
+	if dir == "" {
+		return
+	}
+	defaultLoader = liveLoader{dir: dir}
+}
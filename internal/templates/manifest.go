@@ -0,0 +1,53 @@
+package templates
+
+import (
+	"fmt"
+	"io/fs"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ManifestFile is the name every non-default template tree must ship,
+// declaring the directories it creates and the files it renders.
+const ManifestFile = "scaffold.yaml"
+
+// Variable describes a value a template expects in addition to the
+// built-in ProjectConfig fields.
+type Variable struct {
+	Description string `yaml:"description"`
+	Default     string `yaml:"default"`
+	Required    bool   `yaml:"required"`
+}
+
+// Manifest describes a template tree: the directories it creates and the
+// template files it renders into the generated project. It replaces the
+// hard-coded DirectoryStructure/file map used by the default template for
+// every template resolved via Source other than the built-in default.
+type Manifest struct {
+	Name        string   `yaml:"name"`
+	Version     string   `yaml:"version"`
+	Description string   `yaml:"description"`
+	Directories []string `yaml:"directories"`
+
+	// Files maps a destination path, relative to the generated project
+	// root, to a template file path relative to the template root.
+	Files map[string]string `yaml:"files"`
+
+	Variables map[string]Variable `yaml:"variables"`
+}
+
+// LoadManifest reads and parses the scaffold.yaml manifest from a
+// resolved template tree.
+func LoadManifest(tmplFS fs.FS) (*Manifest, error) {
+	content, err := fs.ReadFile(tmplFS, ManifestFile)
+	if err != nil {
+		return nil, fmt.Errorf("template is missing %s: %w", ManifestFile, err)
+	}
+
+	var m Manifest
+	if err := yaml.Unmarshal(content, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", ManifestFile, err)
+	}
+
+	return &m, nil
+}
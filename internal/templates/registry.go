@@ -0,0 +1,183 @@
+package templates
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Source resolves a template reference (a built-in name, a local
+// directory, or a remote git URL) into a filesystem tree that can be read
+// by GetTemplate, GetWorkflowFile and LoadManifest.
+type Source interface {
+	// FS returns the filesystem rooted at the template tree.
+	FS() (fs.FS, error)
+	// Describe returns a short human-readable identifier, used in error
+	// messages and dry-run previews.
+	Describe() string
+}
+
+// builtinSource resolves to a template embedded in the scaffold binary.
+type builtinSource struct {
+	name string
+}
+
+func (s *builtinSource) FS() (fs.FS, error) {
+	if s.name == "default" {
+		return defaultLoader.FS()
+	}
+	sub, err := fs.Sub(templateFS, filepath.Join("files", "templates", s.name))
+	if err != nil {
+		return nil, fmt.Errorf("unknown built-in template %q: %w", s.name, err)
+	}
+	// fs.Sub succeeds even for a path that doesn't exist; confirm it does.
+	if _, err := fs.Stat(sub, "."); err != nil {
+		return nil, fmt.Errorf("unknown built-in template %q", s.name)
+	}
+	return sub, nil
+}
+
+func (s *builtinSource) Describe() string { return "built-in:" + s.name }
+
+// localSource resolves to a template tree that already lives on disk.
+type localSource struct {
+	path string
+}
+
+func (s *localSource) FS() (fs.FS, error) {
+	abs, err := filepath.Abs(s.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve template path %s: %w", s.path, err)
+	}
+	if info, err := os.Stat(abs); err != nil || !info.IsDir() {
+		return nil, fmt.Errorf("template directory %s does not exist", abs)
+	}
+	return os.DirFS(abs), nil
+}
+
+func (s *localSource) Describe() string { return s.path }
+
+// gitSource resolves to a shallow clone of a remote git repository, cached
+// under $XDG_CACHE_HOME/scaffold/templates/<hash> so repeated `scaffold
+// new` invocations don't re-clone an unchanged template.
+type gitSource struct {
+	url string
+	ref string
+}
+
+func (s *gitSource) FS() (fs.FS, error) {
+	dir, err := templateCacheDir(s.url, s.ref)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, ".git")); err != nil {
+		if err := cloneTemplate(s.url, s.ref, dir); err != nil {
+			return nil, err
+		}
+	}
+
+	return os.DirFS(dir), nil
+}
+
+func (s *gitSource) Describe() string {
+	if s.ref != "" {
+		return s.url + "@" + s.ref
+	}
+	return s.url
+}
+
+func cloneTemplate(url, ref, dest string) error {
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return fmt.Errorf("failed to create template cache dir: %w", err)
+	}
+
+	args := []string{"clone", "--depth", "1"}
+	if ref != "" {
+		args = append(args, "--branch", ref)
+	}
+	args = append(args, url, dest)
+
+	if out, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to clone template %s: %w\n%s", url, err, out)
+	}
+
+	return nil
+}
+
+// templateCacheDir returns the deterministic cache path for a cloned
+// template repository.
+func templateCacheDir(url, ref string) (string, error) {
+	base := os.Getenv("XDG_CACHE_HOME")
+	if base == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve cache directory: %w", err)
+		}
+		base = filepath.Join(home, ".cache")
+	}
+
+	sum := sha256.Sum256([]byte(url + "@" + ref))
+	return filepath.Join(base, "scaffold", "templates", hex.EncodeToString(sum[:])[:16]), nil
+}
+
+// gitRefPattern matches template references that point at a remote git
+// host rather than a local path or built-in name.
+var gitRefPattern = regexp.MustCompile(`^(https://|git@|github\.com/|gitlab\.com/)`)
+
+// ResolveTemplate turns a --template value into a Source:
+//   - "" or "default" resolves to the built-in default template set
+//   - a value that exists as a directory on disk resolves to a local source
+//   - a value that looks like a git host (github.com/..., https://...,
+//     git@...), optionally suffixed with @ref, resolves to a shallow clone
+//   - anything else is treated as the name of a built-in template
+func ResolveTemplate(ref string) (Source, error) {
+	if ref == "" || ref == "default" {
+		return &builtinSource{name: "default"}, nil
+	}
+
+	if info, err := os.Stat(ref); err == nil && info.IsDir() {
+		return &localSource{path: ref}, nil
+	}
+
+	if gitRefPattern.MatchString(ref) {
+		// Only an @ after the last "/" is a ref suffix; the one in a
+		// git@host: prefix is part of the URL.
+		url, tag := ref, ""
+		if i := strings.LastIndex(ref, "@"); i > strings.LastIndex(ref, "/") {
+			url, tag = ref[:i], ref[i+1:]
+		}
+		if !strings.Contains(url, "://") && !strings.HasPrefix(url, "git@") {
+			url = "https://" + url
+		}
+		return &gitSource{url: url, ref: tag}, nil
+	}
+
+	return &builtinSource{name: ref}, nil
+}
+
+// ListBuiltinTemplates returns the names of named templates embedded in
+// the binary (in addition to the unnamed "default" template), for use in
+// `scaffold new`'s interactive template picker.
+func ListBuiltinTemplates() ([]string, error) {
+	entries, err := templateFS.ReadDir("files/templates")
+	if err != nil {
+		// No files/templates subtree means only the default built-in
+		// template is available.
+		return nil, nil
+	}
+
+	var names []string
+	for _, e := range entries {
+		if e.IsDir() {
+			names = append(names, e.Name())
+		}
+	}
+	return names, nil
+}
@@ -2,6 +2,7 @@ package templates
 
 import (
 	"embed"
+	"io/fs"
 	"text/template"
 )
 
@@ -24,20 +25,29 @@ type ProjectConfig struct {
 	Author      string
 	License     string
 	Type        ProjectType
+
+	// Framework is the web/CLI framework to build the project on, valid
+	// values depending on Type (see FrameworksFor). Empty means none.
+	Framework Framework
+
+	// Template is the --template reference (a built-in name, a local
+	// directory, or a git URL) used to resolve a Source. Empty means the
+	// built-in default template.
+	Template string
 }
 
-// GetTemplate returns a parsed template by name
-func GetTemplate(name string) (*template.Template, error) {
-	content, err := templateFS.ReadFile("files/" + name)
+// GetTemplate returns a template named name parsed out of tmplFS.
+func GetTemplate(tmplFS fs.FS, name string) (*template.Template, error) {
+	content, err := fs.ReadFile(tmplFS, name)
 	if err != nil {
 		return nil, err
 	}
 	return template.New(name).Parse(string(content))
 }
 
-// GetWorkflowFile returns the raw content of a workflow file
-func GetWorkflowFile(name string) ([]byte, error) {
-	return templateFS.ReadFile("files/" + name)
+// GetWorkflowFile returns the raw content of a workflow file out of tmplFS.
+func GetWorkflowFile(tmplFS fs.FS, name string) ([]byte, error) {
+	return fs.ReadFile(tmplFS, name)
 }
 
 // DirectoryStructure returns the directories to create based on project type